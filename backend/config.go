@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+)
+
+// Config holds the backend's mount configuration, as stored via the
+// plugin's config path and read back by b.configured on every request.
+type Config struct {
+	// Network is the eth2 network (mainnet, prater, ...) this mount signs
+	// for, used to derive slashing-protection domains and genesis data.
+	Network core.Network
+
+	// SlashingProtectionMode selects where slashing protection state is
+	// kept: SlashingProtectionModeLocal (default) or
+	// SlashingProtectionModeRemote.
+	SlashingProtectionMode string
+
+	// SlashingProtectionRemoteEndpoint is the host:port of the remote
+	// slashing protection database, used when SlashingProtectionMode is
+	// SlashingProtectionModeRemote.
+	SlashingProtectionRemoteEndpoint string
+
+	// SlashingProtectionRemoteTLSCertFile, ...KeyFile and ...CAFile locate
+	// the client certificate, key and CA bundle used to authenticate to the
+	// remote slashing protection database. Leaving all three empty dials
+	// insecurely, which should only be used for local testing.
+	SlashingProtectionRemoteTLSCertFile string
+	SlashingProtectionRemoteTLSKeyFile  string
+	SlashingProtectionRemoteTLSCAFile   string
+
+	// DoppelgangerBeaconNodes lists the beacon-node API base URLs queried
+	// for validator liveness before a freshly loaded key is trusted to
+	// sign. Leaving it empty disables doppelganger protection entirely.
+	DoppelgangerBeaconNodes []string
+
+	// DoppelgangerEpochs is how many epochs, counted from a key's first
+	// liveness check that found it not live, the remote liveness check keeps
+	// running before it's skipped on subsequent signs. A live report inside
+	// that window refuses the sign but does not restart the count.
+	DoppelgangerEpochs uint64
+}
+
+// SlashingProtectionRemoteTLSCredentials loads the client TLS credentials
+// for dialing the remote slashing protection database, or returns nil
+// credentials (an insecure dial) if none are configured.
+func (c *Config) SlashingProtectionRemoteTLSCredentials() (credentials.TransportCredentials, error) {
+	if c.SlashingProtectionRemoteTLSCertFile == "" && c.SlashingProtectionRemoteTLSKeyFile == "" && c.SlashingProtectionRemoteTLSCAFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.SlashingProtectionRemoteTLSCertFile, c.SlashingProtectionRemoteTLSKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+
+	caBytes, err := ioutil.ReadFile(c.SlashingProtectionRemoteTLSCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA bundle")
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, errors.Errorf("failed to parse CA bundle %q", c.SlashingProtectionRemoteTLSCAFile)
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}), nil
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/key-vault/backend/store"
+)
+
+// DoppelgangerCheckPattern is the path pattern for the doppelganger check
+// endpoint.
+const DoppelgangerCheckPattern = "accounts/doppelganger-check"
+
+// livenessPath is the beacon-node endpoint queried for validator liveness,
+// as defined by the beacon-node API.
+const livenessPath = "/eth/v1/validator/liveness/%d"
+
+// livenessRequestTimeout bounds how long a single beacon node gets to
+// answer a liveness query. checkDoppelganger runs this while the signing
+// account's DBLock is held, so an unresponsive beacon node must not be
+// able to hang it indefinitely.
+const livenessRequestTimeout = 5 * time.Second
+
+var doppelgangerHTTPClient = &http.Client{Timeout: livenessRequestTimeout}
+
+func doppelgangerPaths(b *backend) []*framework.Path {
+	return []*framework.Path{
+		&framework.Path{
+			Pattern:         DoppelgangerCheckPattern,
+			HelpSynopsis:    "Check whether a public key is live on another beacon node",
+			HelpDescription: `Queries every configured beacon node's liveness endpoint for public_key at the given epoch and reports whether it is being attested to elsewhere.`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"epoch": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Epoch to check liveness at",
+					Default:     0,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathDoppelgangerCheck,
+			},
+		},
+	}
+}
+
+func (b *backend) pathDoppelgangerCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	publicKey := data.Get("public_key").(string)
+	epoch := uint64(data.Get("epoch").(int))
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+
+	live, err := b.queryDoppelganger(config, publicKey, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query doppelganger liveness")
+	}
+
+	if !live {
+		if err := storage.SaveDoppelgangerTrustedEpoch(publicKey, epoch); err != nil {
+			return nil, errors.Wrap(err, "failed to save doppelganger trusted epoch")
+		}
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"live": live,
+		},
+	}, nil
+}
+
+// checkDoppelganger gates signing on the doppelganger-protection window: it
+// refuses to sign if any configured beacon node reports the key live
+// elsewhere. The epoch of the first check that finds no liveness is stored
+// as the key's trusted-since epoch; requests at or after trusted-since plus
+// config.DoppelgangerEpochs skip the remote check, earlier ones still run it.
+func (b *backend) checkDoppelganger(config *Config, storage *store.HashicorpVaultStore, publicKey string, epoch uint64) error {
+	if len(config.DoppelgangerBeaconNodes) == 0 {
+		return nil
+	}
+
+	trustedSince, found, err := storage.RetrieveDoppelgangerTrustedEpoch(publicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve doppelganger trusted epoch")
+	}
+
+	if found && epoch >= trustedSince+config.DoppelgangerEpochs {
+		return nil
+	}
+
+	live, err := b.queryDoppelganger(config, publicKey, epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to query doppelganger liveness")
+	}
+
+	if live {
+		return errors.Errorf("refusing to sign: public key %q was reported live by a beacon node within the doppelganger-protection window", publicKey)
+	}
+
+	if !found {
+		if err := storage.SaveDoppelgangerTrustedEpoch(publicKey, epoch); err != nil {
+			return errors.Wrap(err, "failed to save doppelganger trusted epoch")
+		}
+	}
+
+	return nil
+}
+
+// queryDoppelganger asks every configured beacon node whether publicKey is
+// live at epoch, returning true as soon as any of them says so.
+func (b *backend) queryDoppelganger(config *Config, publicKey string, epoch uint64) (bool, error) {
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	body, err := json.Marshal([]string{hex.EncodeToString(publicKeyBytes)})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal liveness request")
+	}
+
+	for _, endpoint := range config.DoppelgangerBeaconNodes {
+		url := endpoint + fmt.Sprintf(livenessPath, epoch)
+
+		resp, err := doppelgangerHTTPClient.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to query beacon node %q", endpoint)
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return false, errors.Errorf("beacon node %q returned status %d for liveness query", endpoint, resp.StatusCode)
+		}
+
+		var parsed livenessResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return false, errors.Wrapf(decodeErr, "failed to parse liveness response from %q", endpoint)
+		}
+
+		for _, record := range parsed.Data {
+			if record.IsLive {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// livenessResponse is the beacon-node API response to a liveness query.
+type livenessResponse struct {
+	Data []livenessRecord `json:"data"`
+}
+
+type livenessRecord struct {
+	PublicKey string `json:"public_key"`
+	IsLive    bool   `json:"is_live"`
+}
@@ -0,0 +1,361 @@
+package backend
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	vault "github.com/bloxapp/eth2-key-manager"
+	"github.com/bloxapp/eth2-key-manager/slashing_protection"
+	"github.com/bloxapp/eth2-key-manager/validator_signer"
+	"github.com/bloxapp/eth2-key-manager/wallet_hd"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	v1 "github.com/wealdtech/eth2-signer-api/pb/v1"
+
+	"github.com/bloxapp/key-vault/backend/store"
+)
+
+// SignAttestationBatchPattern is the path pattern for the batch attestation
+// sign endpoint.
+const SignAttestationBatchPattern = "accounts/sign-attestation-batch"
+
+// batchSignWorkers bounds how many BLS signatures are produced in
+// parallel per batch request, so one oversized batch can't starve Vault of
+// worker goroutines.
+const batchSignWorkers = 8
+
+func signAttestationBatchPaths(b *backend) []*framework.Path {
+	return []*framework.Path{
+		&framework.Path{
+			Pattern:         SignAttestationBatchPattern,
+			HelpSynopsis:    "Sign a batch of attestations",
+			HelpDescription: `Signs many attestations in one call, grouping requests that share identical attestation data so the signing root is computed once per unique data instead of once per request.`,
+			Fields: map[string]*framework.FieldSchema{
+				"attestations": &framework.FieldSchema{
+					Type:        framework.TypeSlice,
+					Description: "Array of {public_key, domain, slot, committeeIndex, beaconBlockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot} objects",
+					Default:     nil,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignAttestationBatch,
+			},
+		},
+	}
+}
+
+// attestationBatchRequest is a single entry of the "attestations" array.
+type attestationBatchRequest struct {
+	PublicKey       string `mapstructure:"public_key"`
+	Domain          string `mapstructure:"domain"`
+	Slot            int    `mapstructure:"slot"`
+	CommitteeIndex  int    `mapstructure:"committeeIndex"`
+	BeaconBlockRoot string `mapstructure:"beaconBlockRoot"`
+	SourceEpoch     int    `mapstructure:"sourceEpoch"`
+	SourceRoot      string `mapstructure:"sourceRoot"`
+	TargetEpoch     int    `mapstructure:"targetEpoch"`
+	TargetRoot      string `mapstructure:"targetRoot"`
+}
+
+// attestationBatchResult is the per-index outcome returned for each entry
+// of the "attestations" array, in the same order it was submitted.
+type attestationBatchResult struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (b *backend) pathSignAttestationBatch(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	// Load config
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	// bring up KeyVault and wallet
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	var requests []attestationBatchRequest
+	if err := mapstructure.Decode(data.Get("attestations"), &requests); err != nil {
+		return nil, errors.Wrap(err, "failed to parse attestations")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+
+	results := make([]attestationBatchResult, len(requests))
+
+	// Group identical (domain, AttestationData) pairs so the SSZ signing
+	// root is computed once no matter how many validators in the committee
+	// share it; only the per-key slashing check and BLS signature remain
+	// per request, since both are inherently specific to each validator.
+	groups := make(map[attestationDataKey][]int)
+	for i, r := range requests {
+		key := attestationDataKeyOf(r)
+		groups[key] = append(groups[key], i)
+	}
+
+	// Every account touched in this batch needs its DBLock held for the
+	// duration of its signature, and many groups can share an account, so
+	// accounts are locked in a fixed order up front rather than per-group to
+	// avoid deadlocking against a concurrent batch that locks the same set
+	// in a different order. Each account also gets an in-process mutex:
+	// the DBLock only keeps this request's locks apart from a concurrent
+	// request's, but two groups within this same batch can reference the
+	// same public key and run on different workers, so the check-then-sign
+	// step below still needs its own per-account serialization.
+	type accountLock struct {
+		sortKey string
+		dbLock  *DBLock
+		mu      sync.Mutex
+	}
+
+	seenAccountIDs := make(map[string]bool)
+	var accountLocks []*accountLock
+	for _, r := range requests {
+		account, err := wallet.AccountByPublicKey(r.PublicKey)
+		if err != nil {
+			if err == wallet_hd.ErrAccountNotFound {
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "failed to retrieve account for public key %q", r.PublicKey)
+		}
+
+		id := account.ID()
+		sortKey := id.String()
+		if seenAccountIDs[sortKey] {
+			continue
+		}
+		seenAccountIDs[sortKey] = true
+
+		accountLocks = append(accountLocks, &accountLock{sortKey: sortKey, dbLock: NewDBLock(id, req.Storage)})
+	}
+
+	sort.Slice(accountLocks, func(i, j int) bool {
+		return accountLocks[i].sortKey < accountLocks[j].sortKey
+	})
+
+	for i, al := range accountLocks {
+		if err := al.dbLock.Lock(); err != nil {
+			for _, l := range accountLocks[:i] {
+				l.dbLock.UnLock()
+			}
+			return nil, errors.Wrapf(err, "failed to lock account %s", al.sortKey)
+		}
+	}
+	defer func() {
+		for _, l := range accountLocks {
+			l.dbLock.UnLock()
+		}
+	}()
+
+	accountMutexes := make(map[string]*sync.Mutex, len(accountLocks))
+	for _, al := range accountLocks {
+		accountMutexes[al.sortKey] = &al.mu
+	}
+
+	type job struct {
+		key     attestationDataKey
+		indexes []int
+	}
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	for w := 0; w < batchSignWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				signBatchGroup(wallet, protector, accountMutexes, requests, j.indexes, results)
+			}
+		}()
+	}
+
+	for key, indexes := range groups {
+		jobs <- job{key: key, indexes: indexes}
+	}
+	close(jobs)
+	wg.Wait()
+
+	response := make([]map[string]interface{}, len(results))
+	for i, r := range results {
+		response[i] = map[string]interface{}{}
+		if r.Signature != "" {
+			response[i]["signature"] = r.Signature
+		}
+		if r.Error != "" {
+			response[i]["error"] = r.Error
+		}
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"results": response,
+		},
+	}, nil
+}
+
+// attestationDataKey identifies requests that share an identical domain and
+// AttestationData, and therefore an identical SSZ signing root, even though
+// they're signed by different public keys.
+type attestationDataKey struct {
+	domain          string
+	slot            int
+	committeeIndex  int
+	beaconBlockRoot string
+	sourceEpoch     int
+	sourceRoot      string
+	targetEpoch     int
+	targetRoot      string
+}
+
+func attestationDataKeyOf(r attestationBatchRequest) attestationDataKey {
+	return attestationDataKey{
+		domain:          r.Domain,
+		slot:            r.Slot,
+		committeeIndex:  r.CommitteeIndex,
+		beaconBlockRoot: r.BeaconBlockRoot,
+		sourceEpoch:     r.SourceEpoch,
+		sourceRoot:      r.SourceRoot,
+		targetEpoch:     r.TargetEpoch,
+		targetRoot:      r.TargetRoot,
+	}
+}
+
+// signBatchGroup signs every request in indexes, all sharing an identical
+// domain and AttestationData. The SSZ signing root is decoded and computed
+// exactly once for the whole group; only the per-key slashing check and BLS
+// signature are repeated per index, writing each outcome into results at
+// its original index. accountMutexes serializes the check-then-sign section
+// per account, since two groups sharing a public key can otherwise run
+// concurrently on different workers.
+func signBatchGroup(wallet vault.Wallet, protector slashing_protection.Protector, accountMutexes map[string]*sync.Mutex, requests []attestationBatchRequest, indexes []int, results []attestationBatchResult) {
+	template := requests[indexes[0]]
+
+	domainBytes, err := hex.DecodeString(template.Domain)
+	if err != nil {
+		failAll(results, indexes, errors.Wrap(err, "failed to HEX decode domain"))
+		return
+	}
+
+	beaconBlockRootBytes, err := hex.DecodeString(template.BeaconBlockRoot)
+	if err != nil {
+		failAll(results, indexes, errors.Wrap(err, "failed to HEX decode beacon block root"))
+		return
+	}
+
+	sourceRootBytes, err := hex.DecodeString(template.SourceRoot)
+	if err != nil {
+		failAll(results, indexes, errors.Wrap(err, "failed to HEX decode source root"))
+		return
+	}
+
+	targetRootBytes, err := hex.DecodeString(template.TargetRoot)
+	if err != nil {
+		failAll(results, indexes, errors.Wrap(err, "failed to HEX decode target root"))
+		return
+	}
+
+	attestationData := &v1.AttestationData{
+		Slot:            uint64(template.Slot),
+		CommitteeIndex:  uint64(template.CommitteeIndex),
+		BeaconBlockRoot: beaconBlockRootBytes,
+		Source: &v1.Checkpoint{
+			Epoch: uint64(template.SourceEpoch),
+			Root:  sourceRootBytes,
+		},
+		Target: &v1.Checkpoint{
+			Epoch: uint64(template.TargetEpoch),
+			Root:  targetRootBytes,
+		},
+	}
+
+	// The SSZ signing root only depends on domain + attestationData, both
+	// identical across indexes, so it's computed once for the whole group.
+	signingRoot, err := validator_signer.AttestationSigningRoot(domainBytes, attestationData)
+	if err != nil {
+		failAll(results, indexes, errors.Wrap(err, "failed to compute attestation signing root"))
+		return
+	}
+
+	for _, i := range indexes {
+		r := requests[i]
+
+		publicKeyBytes, err := hex.DecodeString(r.PublicKey)
+		if err != nil {
+			results[i] = attestationBatchResult{Error: errors.Wrap(err, "failed to HEX decode public key").Error()}
+			continue
+		}
+
+		// Account existence is confirmed before the slashing check runs, so a
+		// public key that doesn't resolve to an account never advances the
+		// slashing-protection high-water mark without a signature to show
+		// for it.
+		account, err := wallet.AccountByPublicKey(r.PublicKey)
+		if err != nil {
+			results[i] = attestationBatchResult{Error: errors.Wrap(err, "failed to retrieve account").Error()}
+			continue
+		}
+
+		// The check-then-sign section is serialized per account: two groups
+		// in this batch can share a public key and run on different
+		// workers, and without this lock they could both pass the slashing
+		// check for conflicting attestations before either signs.
+		mu := accountMutexes[account.ID().String()]
+		mu.Lock()
+
+		// The slashing check is necessarily per validator: it compares this
+		// attestation against this public key's own prior votes.
+		slashable, err := protector.IsSlashableAttestation(publicKeyBytes, &v1.SignBeaconAttestationRequest{
+			Id:     &v1.SignBeaconAttestationRequest_PublicKey{PublicKey: publicKeyBytes},
+			Domain: domainBytes,
+			Data:   attestationData,
+		})
+		if err != nil {
+			mu.Unlock()
+			results[i] = attestationBatchResult{Error: errors.Wrap(err, "failed to check slashing protection").Error()}
+			continue
+		}
+		if slashable {
+			mu.Unlock()
+			results[i] = attestationBatchResult{Error: "slashable attestation"}
+			continue
+		}
+
+		signature, err := account.Sign(signingRoot)
+		mu.Unlock()
+		if err != nil {
+			results[i] = attestationBatchResult{Error: errors.Wrap(err, "failed to sign attestation").Error()}
+			continue
+		}
+
+		results[i] = attestationBatchResult{Signature: hex.EncodeToString(signature.Marshal())}
+	}
+}
+
+// failAll records err against every index in indexes, used when a failure
+// is shared by the whole group (e.g. the group's data itself is malformed).
+func failAll(results []attestationBatchResult, indexes []int, err error) {
+	for _, i := range indexes {
+		results[i] = attestationBatchResult{Error: err.Error()}
+	}
+}
@@ -0,0 +1,57 @@
+package backend
+
+import "testing"
+
+func TestAttestationDataKeyOfGroupsIdenticalRequests(t *testing.T) {
+	a := attestationBatchRequest{
+		PublicKey:       "aa",
+		Domain:          "domain",
+		Slot:            1,
+		CommitteeIndex:  2,
+		BeaconBlockRoot: "root",
+		SourceEpoch:     3,
+		SourceRoot:      "source",
+		TargetEpoch:     4,
+		TargetRoot:      "target",
+	}
+	b := a
+	b.PublicKey = "bb"
+
+	if attestationDataKeyOf(a) != attestationDataKeyOf(b) {
+		t.Fatalf("requests differing only by public key should share an attestationDataKey")
+	}
+}
+
+func TestAttestationDataKeyOfSeparatesDifferentData(t *testing.T) {
+	a := attestationBatchRequest{Domain: "domain", Slot: 1, TargetEpoch: 4}
+	b := a
+	b.Slot = 2
+
+	if attestationDataKeyOf(a) == attestationDataKeyOf(b) {
+		t.Fatalf("requests with different slots must not share an attestationDataKey")
+	}
+}
+
+func TestFailAllRecordsErrorAgainstEveryIndex(t *testing.T) {
+	results := make([]attestationBatchResult, 4)
+	indexes := []int{1, 3}
+
+	failAll(results, indexes, errDummy)
+
+	for _, i := range indexes {
+		if results[i].Error != errDummy.Error() {
+			t.Errorf("results[%d].Error = %q, want %q", i, results[i].Error, errDummy.Error())
+		}
+	}
+	for _, i := range []int{0, 2} {
+		if results[i].Error != "" {
+			t.Errorf("results[%d].Error = %q, want empty", i, results[i].Error)
+		}
+	}
+}
+
+var errDummy = dummyErr{}
+
+type dummyErr struct{}
+
+func (dummyErr) Error() string { return "dummy error" }
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"encoding/hex"
+	"sync"
 
 	vault "github.com/bloxapp/eth2-key-manager"
 	"github.com/bloxapp/eth2-key-manager/slashing_protection"
@@ -13,9 +14,62 @@ import (
 	"github.com/pkg/errors"
 	v1 "github.com/wealdtech/eth2-signer-api/pb/v1"
 
+	"github.com/bloxapp/key-vault/backend/remote_protection"
 	"github.com/bloxapp/key-vault/backend/store"
 )
 
+// Slashing protection modes, selected via config.SlashingProtectionMode.
+const (
+	// SlashingProtectionModeLocal keeps slashing state in the Vault-backed
+	// storage, scoped to this single key-vault instance.
+	SlashingProtectionModeLocal = "local"
+
+	// SlashingProtectionModeRemote delegates slashing checks to an external
+	// SLASHINDB gRPC service shared by every key-vault instance behind a
+	// load balancer, so horizontally scaling the signer can't equivocate.
+	SlashingProtectionModeRemote = "remote"
+)
+
+// remoteProtectorCache holds one dialed *remote_protection.Protector per
+// endpoint, so concurrent sign requests share a single gRPC connection
+// instead of each dialing and leaking their own.
+var (
+	remoteProtectorCacheMu sync.Mutex
+	remoteProtectorCache   = map[string]*remote_protection.Protector{}
+)
+
+// protector builds the slashing_protection.Protector to use for this
+// request, based on config.SlashingProtectionMode. Defaulting to local
+// keeps existing single-instance deployments behaving exactly as before.
+func (b *backend) protector(config *Config, storage *store.HashicorpVaultStore) (slashing_protection.Protector, error) {
+	if config.SlashingProtectionMode != SlashingProtectionModeRemote {
+		return slashing_protection.NewNormalProtection(storage), nil
+	}
+
+	endpoint := config.SlashingProtectionRemoteEndpoint
+
+	remoteProtectorCacheMu.Lock()
+	defer remoteProtectorCacheMu.Unlock()
+
+	if cached, ok := remoteProtectorCache[endpoint]; ok {
+		return cached, nil
+	}
+
+	tlsCreds, err := config.SlashingProtectionRemoteTLSCredentials()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load remote slashing protection TLS credentials")
+	}
+
+	remoteProtector, err := remote_protection.Dial(endpoint, tlsCreds)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to remote slashing protection database")
+	}
+
+	remoteProtectorCache[endpoint] = remoteProtector
+
+	return remoteProtector, nil
+}
+
 // Endpoints patterns
 const (
 	// SignAttestationPattern is the path pattern for sign attestation endpoint
@@ -29,7 +83,7 @@ const (
 )
 
 func signsPaths(b *backend) []*framework.Path {
-	return []*framework.Path{
+	paths := []*framework.Path{
 		&framework.Path{
 			Pattern:         SignAttestationPattern,
 			HelpSynopsis:    "Sign attestation",
@@ -174,6 +228,26 @@ func signsPaths(b *backend) []*framework.Path {
 			},
 		},
 	}
+
+	// Post-Altair message types (sync committee, voluntary exit, builder-API
+	// validator registration, ...) are kept in their own file but exposed
+	// through the same signsPaths list every sign path is registered in.
+	paths = append(paths, signsAltairPaths(b)...)
+
+	// EIP-3076 slashing-protection interchange import/export are kept in
+	// their own file but exposed through the same signsPaths list every
+	// sign path is registered in.
+	paths = append(paths, slashingProtectionInterchangePaths(b)...)
+
+	// Batch attestation signing is kept in its own file but exposed through
+	// the same signsPaths list every sign path is registered in.
+	paths = append(paths, signAttestationBatchPaths(b)...)
+
+	// Doppelganger protection is kept in its own file but exposed through
+	// the same signsPaths list every sign path is registered in.
+	paths = append(paths, doppelgangerPaths(b)...)
+
+	return paths
 }
 
 func (b *backend) pathSignAttestation(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -226,6 +300,13 @@ func (b *backend) pathSignAttestation(ctx context.Context, req *logical.Request,
 	}
 	defer lock.UnLock()
 
+	// Refuse to sign if this key was recently loaded and is still reported
+	// live on another beacon node, to protect against double-signing while
+	// migrating validators between clusters.
+	if err := b.checkDoppelganger(config, storage, publicKey, uint64(targetEpoch)); err != nil {
+		return nil, err
+	}
+
 	// Decode public key
 	publicKeyBytes, err := hex.DecodeString(publicKey)
 	if err != nil {
@@ -256,7 +337,10 @@ func (b *backend) pathSignAttestation(ctx context.Context, req *logical.Request,
 		return nil, errors.Wrap(err, "failed to HEX decode target root")
 	}
 
-	protector := slashing_protection.NewNormalProtection(storage)
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
 	var signer validator_signer.ValidatorSigner = validator_signer.NewSimpleSigner(wallet, protector)
 
 	res, err := signer.SignBeaconAttestation(&v1.SignBeaconAttestationRequest{
@@ -335,6 +419,13 @@ func (b *backend) pathSignProposal(ctx context.Context, req *logical.Request, da
 	}
 	defer lock.UnLock()
 
+	// Refuse to sign if this key was recently loaded and is still reported
+	// live on another beacon node, to protect against double-signing while
+	// migrating validators between clusters.
+	if err := b.checkDoppelganger(config, storage, publicKey, uint64(slot)/config.Network.SlotsPerEpoch()); err != nil {
+		return nil, err
+	}
+
 	// Decode public key
 	publicKeyBytes, err := hex.DecodeString(publicKey)
 	if err != nil {
@@ -377,7 +468,10 @@ func (b *backend) pathSignProposal(ctx context.Context, req *logical.Request, da
 		},
 	}
 
-	protector := slashing_protection.NewNormalProtection(storage)
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
 	var signer validator_signer.ValidatorSigner = validator_signer.NewSimpleSigner(wallet, protector)
 
 	res, err := signer.SignBeaconProposal(proposalRequest)
@@ -460,7 +554,10 @@ func (b *backend) pathSignAggregation(ctx context.Context, req *logical.Request,
 		Data:   dataToSignBytes,
 	}
 
-	protector := slashing_protection.NewNormalProtection(storage)
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
 	var signer validator_signer.ValidatorSigner = validator_signer.NewSimpleSigner(wallet, protector)
 
 	res, err := signer.Sign(proposalRequest)
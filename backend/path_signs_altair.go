@@ -0,0 +1,999 @@
+package backend
+
+import (
+	"context"
+	"encoding/hex"
+
+	vault "github.com/bloxapp/eth2-key-manager"
+	"github.com/bloxapp/eth2-key-manager/validator_signer"
+	"github.com/bloxapp/eth2-key-manager/wallet_hd"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/key-vault/backend/store"
+)
+
+// Endpoints patterns for post-Altair message types.
+const (
+	// SignSyncCommitteeMessagePattern is the path pattern for sign sync
+	// committee message endpoint.
+	SignSyncCommitteeMessagePattern = "accounts/sign-sync-committee-message"
+
+	// SignSyncCommitteeSelectionProofPattern is the path pattern for sign
+	// sync committee selection proof endpoint.
+	SignSyncCommitteeSelectionProofPattern = "accounts/sign-sync-committee-selection"
+
+	// SignSyncCommitteeContributionAndProofPattern is the path pattern for
+	// sign sync committee contribution and proof endpoint.
+	SignSyncCommitteeContributionAndProofPattern = "accounts/sign-sync-committee-contribution-and-proof"
+
+	// SignVoluntaryExitPattern is the path pattern for sign voluntary exit
+	// endpoint.
+	SignVoluntaryExitPattern = "accounts/sign-voluntary-exit"
+
+	// SignAggregateAndProofPattern is the path pattern for sign aggregate
+	// and proof endpoint.
+	SignAggregateAndProofPattern = "accounts/sign-aggregate-and-proof"
+
+	// SignRandaoRevealPattern is the path pattern for sign randao reveal
+	// endpoint.
+	SignRandaoRevealPattern = "accounts/sign-randao-reveal"
+
+	// SignValidatorRegistrationPattern is the path pattern for sign
+	// validator registration (builder API) endpoint.
+	SignValidatorRegistrationPattern = "accounts/sign-validator-registration"
+)
+
+func signsAltairPaths(b *backend) []*framework.Path {
+	return []*framework.Path{
+		&framework.Path{
+			Pattern:         SignSyncCommitteeMessagePattern,
+			HelpSynopsis:    "Sign sync committee message",
+			HelpDescription: `Sign sync committee message`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"slot": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data Slot",
+					Default:     0,
+				},
+				"beaconBlockRoot": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Data BeaconBlockRoot",
+					Default:     "",
+				},
+				"validatorIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data ValidatorIndex",
+					Default:     0,
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignSyncCommitteeMessage,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignSyncCommitteeSelectionProofPattern,
+			HelpSynopsis:    "Sign sync committee selection proof",
+			HelpDescription: `Sign sync committee selection proof`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"slot": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data Slot",
+					Default:     0,
+				},
+				"subcommitteeIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data SubcommitteeIndex",
+					Default:     0,
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignSyncCommitteeSelectionProof,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignSyncCommitteeContributionAndProofPattern,
+			HelpSynopsis:    "Sign sync committee contribution and proof",
+			HelpDescription: `Sign sync committee contribution and proof`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"aggregatorIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data AggregatorIndex",
+					Default:     0,
+				},
+				"selectionProof": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Data SelectionProof",
+					Default:     "",
+				},
+				"slot": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Contribution Slot",
+					Default:     0,
+				},
+				"beaconBlockRoot": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Contribution BeaconBlockRoot",
+					Default:     "",
+				},
+				"subcommitteeIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Contribution SubcommitteeIndex",
+					Default:     0,
+				},
+				"aggregationBits": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Contribution AggregationBits",
+					Default:     "",
+				},
+				"contributionSignature": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Contribution Signature",
+					Default:     "",
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignSyncCommitteeContributionAndProof,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignVoluntaryExitPattern,
+			HelpSynopsis:    "Sign voluntary exit",
+			HelpDescription: `Sign voluntary exit`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"epoch": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data Epoch",
+					Default:     0,
+				},
+				"validatorIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data ValidatorIndex",
+					Default:     0,
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignVoluntaryExit,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignAggregateAndProofPattern,
+			HelpSynopsis:    "Sign aggregate and proof",
+			HelpDescription: `Sign aggregate and proof`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"aggregatorIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data AggregatorIndex",
+					Default:     0,
+				},
+				"selectionProof": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Data SelectionProof",
+					Default:     "",
+				},
+				"aggregationBits": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Aggregate AggregationBits",
+					Default:     "",
+				},
+				"aggregateSignature": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Aggregate Signature",
+					Default:     "",
+				},
+				"slot": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Aggregate Data Slot",
+					Default:     0,
+				},
+				"committeeIndex": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Aggregate Data CommitteeIndex",
+					Default:     0,
+				},
+				"beaconBlockRoot": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Aggregate Data BeaconBlockRoot",
+					Default:     "",
+				},
+				"sourceEpoch": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Aggregate Data Source Epoch",
+					Default:     0,
+				},
+				"sourceRoot": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Aggregate Data Source Root",
+					Default:     "",
+				},
+				"targetEpoch": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Aggregate Data Target Epoch",
+					Default:     0,
+				},
+				"targetRoot": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Aggregate Data Target Root",
+					Default:     "",
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignAggregateAndProof,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignRandaoRevealPattern,
+			HelpSynopsis:    "Sign randao reveal",
+			HelpDescription: `Sign randao reveal`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"epoch": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Epoch",
+					Default:     0,
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignRandaoReveal,
+			},
+		},
+		&framework.Path{
+			Pattern:         SignValidatorRegistrationPattern,
+			HelpSynopsis:    "Sign validator registration (builder API)",
+			HelpDescription: `Sign validator registration (builder API)`,
+			Fields: map[string]*framework.FieldSchema{
+				"public_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Public key of the account",
+					Default:     "",
+				},
+				"domain": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Domain",
+					Default:     "",
+				},
+				"feeRecipient": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Data FeeRecipient",
+					Default:     "",
+				},
+				"gasLimit": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data GasLimit",
+					Default:     0,
+				},
+				"timestamp": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Data Timestamp",
+					Default:     0,
+				},
+				"pubkey": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Data Pubkey",
+					Default:     "",
+				},
+				"useFakeSigner": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "True if the fake signer should be used",
+					Default:     false,
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSignValidatorRegistration,
+			},
+		},
+	}
+}
+
+func (b *backend) pathSignSyncCommitteeMessage(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	slot := data.Get("slot").(int)
+	beaconBlockRoot := data.Get("beaconBlockRoot").(string)
+	validatorIndex := data.Get("validatorIndex").(int)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	beaconBlockRootBytes, err := hex.DecodeString(beaconBlockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode beacon block root")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignSyncCommitteeMessage(&validator_signer.SignSyncCommitteeMessageRequest{
+		PublicKey:       publicKeyBytes,
+		Domain:          domainBytes,
+		Slot:            uint64(slot),
+		BeaconBlockRoot: beaconBlockRootBytes,
+		ValidatorIndex:  uint64(validatorIndex),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign sync committee message")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignSyncCommitteeSelectionProof(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	slot := data.Get("slot").(int)
+	subcommitteeIndex := data.Get("subcommitteeIndex").(int)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignSyncCommitteeSelectionProof(&validator_signer.SignSyncCommitteeSelectionProofRequest{
+		PublicKey:         publicKeyBytes,
+		Domain:            domainBytes,
+		Slot:              uint64(slot),
+		SubcommitteeIndex: uint64(subcommitteeIndex),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign sync committee selection proof")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignSyncCommitteeContributionAndProof(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	aggregatorIndex := data.Get("aggregatorIndex").(int)
+	selectionProof := data.Get("selectionProof").(string)
+	slot := data.Get("slot").(int)
+	beaconBlockRoot := data.Get("beaconBlockRoot").(string)
+	subcommitteeIndex := data.Get("subcommitteeIndex").(int)
+	aggregationBits := data.Get("aggregationBits").(string)
+	contributionSignature := data.Get("contributionSignature").(string)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	selectionProofBytes, err := hex.DecodeString(selectionProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode selection proof")
+	}
+
+	beaconBlockRootBytes, err := hex.DecodeString(beaconBlockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode beacon block root")
+	}
+
+	aggregationBitsBytes, err := hex.DecodeString(aggregationBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode aggregation bits")
+	}
+
+	contributionSignatureBytes, err := hex.DecodeString(contributionSignature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode contribution signature")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignSyncCommitteeContributionAndProof(&validator_signer.SignSyncCommitteeContributionAndProofRequest{
+		PublicKey:       publicKeyBytes,
+		Domain:          domainBytes,
+		AggregatorIndex: uint64(aggregatorIndex),
+		SelectionProof:  selectionProofBytes,
+		Contribution: &validator_signer.SyncCommitteeContribution{
+			Slot:              uint64(slot),
+			BeaconBlockRoot:   beaconBlockRootBytes,
+			SubcommitteeIndex: uint64(subcommitteeIndex),
+			AggregationBits:   aggregationBitsBytes,
+			Signature:         contributionSignatureBytes,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign sync committee contribution and proof")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignVoluntaryExit(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	epoch := data.Get("epoch").(int)
+	validatorIndex := data.Get("validatorIndex").(int)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignVoluntaryExit(&validator_signer.SignVoluntaryExitRequest{
+		PublicKey:      publicKeyBytes,
+		Domain:         domainBytes,
+		Epoch:          uint64(epoch),
+		ValidatorIndex: uint64(validatorIndex),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignAggregateAndProof(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	aggregatorIndex := data.Get("aggregatorIndex").(int)
+	selectionProof := data.Get("selectionProof").(string)
+	aggregationBits := data.Get("aggregationBits").(string)
+	aggregateSignature := data.Get("aggregateSignature").(string)
+	slot := data.Get("slot").(int)
+	committeeIndex := data.Get("committeeIndex").(int)
+	beaconBlockRoot := data.Get("beaconBlockRoot").(string)
+	sourceEpoch := data.Get("sourceEpoch").(int)
+	sourceRoot := data.Get("sourceRoot").(string)
+	targetEpoch := data.Get("targetEpoch").(int)
+	targetRoot := data.Get("targetRoot").(string)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	selectionProofBytes, err := hex.DecodeString(selectionProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode selection proof")
+	}
+
+	aggregationBitsBytes, err := hex.DecodeString(aggregationBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode aggregation bits")
+	}
+
+	aggregateSignatureBytes, err := hex.DecodeString(aggregateSignature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode aggregate signature")
+	}
+
+	beaconBlockRootBytes, err := hex.DecodeString(beaconBlockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode beacon block root")
+	}
+
+	sourceRootBytes, err := hex.DecodeString(sourceRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode source root")
+	}
+
+	targetRootBytes, err := hex.DecodeString(targetRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode target root")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignAggregateAndProof(&validator_signer.SignAggregateAndProofRequest{
+		PublicKey:       publicKeyBytes,
+		Domain:          domainBytes,
+		AggregatorIndex: uint64(aggregatorIndex),
+		SelectionProof:  selectionProofBytes,
+		Aggregate: &validator_signer.Attestation{
+			AggregationBits: aggregationBitsBytes,
+			Signature:       aggregateSignatureBytes,
+			Data: &validator_signer.AttestationData{
+				Slot:            uint64(slot),
+				CommitteeIndex:  uint64(committeeIndex),
+				BeaconBlockRoot: beaconBlockRootBytes,
+				SourceEpoch:     uint64(sourceEpoch),
+				SourceRoot:      sourceRootBytes,
+				TargetEpoch:     uint64(targetEpoch),
+				TargetRoot:      targetRootBytes,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign aggregate and proof")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignRandaoReveal(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	epoch := data.Get("epoch").(int)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignRandaoReveal(&validator_signer.SignRandaoRevealRequest{
+		PublicKey: publicKeyBytes,
+		Domain:    domainBytes,
+		Epoch:     uint64(epoch),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
+
+func (b *backend) pathSignValidatorRegistration(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	publicKey := data.Get("public_key").(string)
+	domain := data.Get("domain").(string)
+	feeRecipient := data.Get("feeRecipient").(string)
+	gasLimit := data.Get("gasLimit").(int)
+	timestamp := data.Get("timestamp").(int)
+	pubkey := data.Get("pubkey").(string)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	account, err := wallet.AccountByPublicKey(publicKey)
+	if err != nil {
+		if err == wallet_hd.ErrAccountNotFound {
+			return b.notFoundResponse()
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve account")
+	}
+
+	lock := NewDBLock(account.ID(), req.Storage)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	defer lock.UnLock()
+
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode public key")
+	}
+
+	domainBytes, err := hex.DecodeString(domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode domain")
+	}
+
+	feeRecipientBytes, err := hex.DecodeString(feeRecipient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode fee recipient")
+	}
+
+	pubkeyBytes, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to HEX decode pubkey")
+	}
+
+	protector, err := b.protector(config, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build slashing protector")
+	}
+	signer := validator_signer.NewSimpleSigner(wallet, protector)
+
+	res, err := signer.SignValidatorRegistration(&validator_signer.SignValidatorRegistrationRequest{
+		PublicKey: publicKeyBytes,
+		Domain:    domainBytes,
+		Message: &validator_signer.ValidatorRegistration{
+			FeeRecipient: feeRecipientBytes,
+			GasLimit:     uint64(gasLimit),
+			Timestamp:    uint64(timestamp),
+			Pubkey:       pubkeyBytes,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign validator registration")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(res.GetSignature()),
+		},
+	}, nil
+}
@@ -0,0 +1,334 @@
+package backend
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	vault "github.com/bloxapp/eth2-key-manager"
+	"github.com/bloxapp/eth2-key-manager/wallet_hd"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/key-vault/backend/store"
+)
+
+// Endpoints patterns
+const (
+	// SlashingProtectionImportPattern is the path pattern for importing an
+	// EIP-3076 slashing-protection interchange file.
+	SlashingProtectionImportPattern = "accounts/slashing-protection/import"
+
+	// SlashingProtectionExportPattern is the path pattern for exporting the
+	// wallet's slashing protection history as an EIP-3076 interchange file.
+	SlashingProtectionExportPattern = "accounts/slashing-protection/export"
+)
+
+// interchangeFormatVersion is the only EIP-3076 format version key-vault
+// understands.
+const interchangeFormatVersion = "5"
+
+// interchangeFile is the standardized JSON interchange format shared across
+// Ethereum validator clients (EIP-3076).
+type interchangeFile struct {
+	Metadata interchangeMetadata `json:"metadata"`
+	Data     []interchangeRecord `json:"data"`
+}
+
+type interchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type interchangeRecord struct {
+	Pubkey             string                         `json:"pubkey"`
+	SignedBlocks       []interchangeSignedBlock       `json:"signed_blocks"`
+	SignedAttestations []interchangeSignedAttestation `json:"signed_attestations"`
+}
+
+type interchangeSignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type interchangeSignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+func slashingProtectionInterchangePaths(b *backend) []*framework.Path {
+	return []*framework.Path{
+		&framework.Path{
+			Pattern:         SlashingProtectionImportPattern,
+			HelpSynopsis:    "Import an EIP-3076 slashing-protection interchange file",
+			HelpDescription: `Merges the slashing protection history in an EIP-3076 interchange file into the Vault-backed slashing protection store, raising existing high-water marks but never lowering them.`,
+			Fields: map[string]*framework.FieldSchema{
+				"interchange": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "EIP-3076 interchange JSON document",
+					Default:     "",
+				},
+			},
+			ExistenceCheck: b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.CreateOperation: b.pathSlashingProtectionImport,
+			},
+		},
+		&framework.Path{
+			Pattern:         SlashingProtectionExportPattern,
+			HelpSynopsis:    "Export the wallet's slashing protection history as an EIP-3076 interchange file",
+			HelpDescription: `Emits, for every account in the wallet, its highest recorded proposal slot and highest recorded attestation source/target epochs as an EIP-3076 interchange JSON document. Earlier entries and signing roots are not included.`,
+			Fields:          map[string]*framework.FieldSchema{},
+			ExistenceCheck:  b.pathExistenceCheck,
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.ReadOperation: b.pathSlashingProtectionExport,
+			},
+		},
+	}
+}
+
+func (b *backend) pathSlashingProtectionImport(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	// Load config
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	// bring up KeyVault and wallet
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	var file interchangeFile
+	if err := json.Unmarshal([]byte(data.Get("interchange").(string)), &file); err != nil {
+		return nil, errors.Wrap(err, "failed to parse interchange file")
+	}
+
+	if file.Metadata.InterchangeFormatVersion != interchangeFormatVersion {
+		return logical.ErrorResponse("unsupported interchange_format_version %q", file.Metadata.InterchangeFormatVersion), nil
+	}
+
+	if trim0x(file.Metadata.GenesisValidatorsRoot) != trim0x(config.Network.GenesisValidatorsRoot()) {
+		return logical.ErrorResponse("genesis_validators_root does not match the configured network"), nil
+	}
+
+	imported := 0
+	for _, record := range file.Data {
+		if _, err := wallet.AccountByPublicKey(trim0x(record.Pubkey)); err != nil {
+			if err == wallet_hd.ErrAccountNotFound {
+				continue
+			}
+
+			return nil, errors.Wrap(err, "failed to retrieve account")
+		}
+
+		publicKeyBytes, err := hex.DecodeString(trim0x(record.Pubkey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to HEX decode pubkey %q", record.Pubkey)
+		}
+
+		if err := mergeInterchangeRecord(storage, publicKeyBytes, record); err != nil {
+			return nil, errors.Wrapf(err, "failed to merge slashing protection history for pubkey %q", record.Pubkey)
+		}
+
+		imported++
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"imported": imported,
+		},
+	}, nil
+}
+
+func (b *backend) pathSlashingProtectionExport(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	// Load config
+	config, err := b.configured(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+
+	// bring up KeyVault and wallet
+	storage := store.NewHashicorpVaultStore(ctx, req.Storage, config.Network)
+	options := vault.KeyVaultOptions{}
+	options.SetStorage(storage)
+
+	kv, err := vault.OpenKeyVault(&options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open key vault")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	accounts, err := wallet.Accounts()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list wallet accounts")
+	}
+
+	file := interchangeFile{
+		Metadata: interchangeMetadata{
+			InterchangeFormatVersion: interchangeFormatVersion,
+			GenesisValidatorsRoot:    add0x(config.Network.GenesisValidatorsRoot()),
+		},
+	}
+
+	for _, account := range accounts {
+		publicKeyBytes := account.PublicKey().Marshal()
+
+		record, err := exportInterchangeRecord(storage, publicKeyBytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to export slashing protection history for account %q", account.Name())
+		}
+
+		file.Data = append(file.Data, record)
+	}
+
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal interchange file")
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"interchange": string(encoded),
+		},
+	}, nil
+}
+
+func parseUint(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+// mergeInterchangeRecord raises the Vault-backed store's high-water marks
+// for pubKey to the maximum of what's already recorded and what's in
+// record, never lowering an existing mark.
+func mergeInterchangeRecord(storage *store.HashicorpVaultStore, pubKey []byte, record interchangeRecord) error {
+	highestSlot, found, err := storage.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve highest proposal")
+	}
+
+	for _, block := range record.SignedBlocks {
+		slot, err := parseUint(block.Slot)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse slot %q", block.Slot)
+		}
+
+		if !found || slot > highestSlot {
+			if err := storage.SaveHighestProposal(pubKey, slot); err != nil {
+				return errors.Wrap(err, "failed to save highest proposal")
+			}
+
+			highestSlot, found = slot, true
+		}
+	}
+
+	highestSource, highestTarget, found, err := storage.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve highest attestation")
+	}
+
+	for _, attestation := range record.SignedAttestations {
+		sourceEpoch, err := parseUint(attestation.SourceEpoch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse source_epoch %q", attestation.SourceEpoch)
+		}
+
+		targetEpoch, err := parseUint(attestation.TargetEpoch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse target_epoch %q", attestation.TargetEpoch)
+		}
+
+		if !found || sourceEpoch > highestSource {
+			highestSource = sourceEpoch
+		}
+
+		if !found || targetEpoch > highestTarget {
+			highestTarget = targetEpoch
+		}
+
+		found = true
+	}
+
+	if len(record.SignedAttestations) > 0 {
+		if err := storage.SaveHighestAttestation(pubKey, highestSource, highestTarget); err != nil {
+			return errors.Wrap(err, "failed to save highest attestation")
+		}
+	}
+
+	return nil
+}
+
+// exportInterchangeRecord emits pubKey's slashing protection high-water marks
+// as a single EIP-3076 interchange record: at most one signed block (the
+// highest proposal slot) and one signed attestation (the highest source and
+// target epochs), without signing roots.
+func exportInterchangeRecord(storage *store.HashicorpVaultStore, pubKey []byte) (interchangeRecord, error) {
+	record := interchangeRecord{
+		Pubkey: add0x(hex.EncodeToString(pubKey)),
+	}
+
+	slot, found, err := storage.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return record, errors.Wrap(err, "failed to retrieve highest proposal")
+	}
+	if found {
+		record.SignedBlocks = append(record.SignedBlocks, interchangeSignedBlock{
+			Slot: formatUint(slot),
+		})
+	}
+
+	sourceEpoch, targetEpoch, found, err := storage.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return record, errors.Wrap(err, "failed to retrieve highest attestation")
+	}
+	if found {
+		record.SignedAttestations = append(record.SignedAttestations, interchangeSignedAttestation{
+			SourceEpoch: formatUint(sourceEpoch),
+			TargetEpoch: formatUint(targetEpoch),
+		})
+	}
+
+	return record, nil
+}
+
+// trim0x strips an optional "0x" prefix, since interchange files commonly
+// hex-encode pubkeys and roots with it while key-vault's own endpoints don't.
+func trim0x(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+
+	return s
+}
+
+// add0x prefixes s with "0x" if it isn't already, matching the convention
+// other EIP-3076 producers (Prysm, Lighthouse, ...) use for hex-encoded
+// pubkeys and roots, so exported interchange files interoperate with them.
+func add0x(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s
+	}
+
+	return "0x" + s
+}
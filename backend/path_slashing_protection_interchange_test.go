@@ -0,0 +1,42 @@
+package backend
+
+import "testing"
+
+func TestTrim0x(t *testing.T) {
+	cases := map[string]string{
+		"0xabcd": "abcd",
+		"0Xabcd": "abcd",
+		"abcd":   "abcd",
+		"0x":     "",
+		"":       "",
+	}
+
+	for in, want := range cases {
+		if got := trim0x(in); got != want {
+			t.Errorf("trim0x(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAdd0x(t *testing.T) {
+	cases := map[string]string{
+		"abcd":   "0xabcd",
+		"0xabcd": "0xabcd",
+		"0Xabcd": "0Xabcd",
+		"":       "0x",
+	}
+
+	for in, want := range cases {
+		if got := add0x(in); got != want {
+			t.Errorf("add0x(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAdd0xTrim0xRoundTrip(t *testing.T) {
+	for _, in := range []string{"abcd1234", "0xabcd1234"} {
+		if got := trim0x(add0x(in)); got != trim0x(in) {
+			t.Errorf("trim0x(add0x(%q)) = %q, want %q", in, got, trim0x(in))
+		}
+	}
+}
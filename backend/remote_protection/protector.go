@@ -0,0 +1,104 @@
+// Package remote_protection implements slashing_protection.Protector by
+// delegating every check to an external, horizontally-shared slashing
+// database over gRPC, instead of the Vault-local store used by
+// slashing_protection.NewNormalProtection. This lets several key-vault
+// instances sit behind a load balancer and share one authoritative source
+// of truth, which a per-instance local DB cannot provide.
+package remote_protection
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	eth "github.com/wealdtech/eth2-signer-api/pb/v1"
+
+	"github.com/bloxapp/key-vault/backend/remote_protection/pb"
+)
+
+// requestTimeout bounds each round trip to the remote slashing DB so a
+// signing request can't hang forever behind a stalled load balancer.
+const requestTimeout = 5 * time.Second
+
+// Protector delegates slashing protection checks to a remote SLASHINDB
+// gRPC service, sending only the minimal state required: the attestation's
+// source/target epochs or the proposal's slot, per public key, which the
+// remote service compares against what it previously recorded.
+type Protector struct {
+	client pb.SlashingDBClient
+	conn   *grpc.ClientConn
+}
+
+// Dial opens a gRPC connection to the remote slashing DB at endpoint. When
+// tlsCreds is nil the connection is established insecurely, which should
+// only be used for local testing.
+func Dial(endpoint string, tlsCreds credentials.TransportCredentials) (*Protector, error) {
+	var opts []grpc.DialOption
+	if tlsCreds != nil {
+		opts = append(opts, grpc.WithTransportCredentials(tlsCreds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial remote slashing protection database")
+	}
+
+	return &Protector{
+		client: pb.NewSlashingDBClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// Close tears down the underlying gRPC connection.
+func (p *Protector) Close() error {
+	return p.conn.Close()
+}
+
+// IsSlashableAttestation asks the remote slashing DB whether signing the
+// given attestation would be slashable for pubKey, and records the new
+// source/target epochs when it isn't.
+func (p *Protector) IsSlashableAttestation(pubKey []byte, req *eth.SignBeaconAttestationRequest) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	decision, err := p.client.CheckAttestation(ctx, &pb.CheckAttestationRequest{
+		PublicKey:   pubKey,
+		SourceEpoch: req.GetData().GetSource().GetEpoch(),
+		TargetEpoch: req.GetData().GetTarget().GetEpoch(),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check attestation with remote slashing protection database")
+	}
+
+	if decision.GetSlashable() {
+		return true, errors.Errorf("slashable attestation: %s", decision.GetReason())
+	}
+
+	return false, nil
+}
+
+// IsSlashableProposal asks the remote slashing DB whether signing the given
+// proposal would be slashable for pubKey, and records the new slot when it
+// isn't.
+func (p *Protector) IsSlashableProposal(pubKey []byte, req *eth.SignBeaconProposalRequest) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	decision, err := p.client.CheckProposal(ctx, &pb.CheckProposalRequest{
+		PublicKey: pubKey,
+		Slot:      req.GetData().GetSlot(),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check proposal with remote slashing protection database")
+	}
+
+	if decision.GetSlashable() {
+		return true, errors.Errorf("slashable proposal: %s", decision.GetReason())
+	}
+
+	return false, nil
+}